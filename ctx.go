@@ -0,0 +1,220 @@
+package logger
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// logCtx is the context-aware counterpart of (*Logger).log: in addition to
+// everything log does, it populates trace_id/span_id/trace_flags from any
+// OpenTelemetry span found in ctx, and for Error/Fatal rows it records the
+// message as an error on that span.
+func (l *Logger) logCtx(ctx context.Context, severity int, event interface{}, suppressed uint64, format string, a ...interface{}) {
+	if severity > l.CurrentLogSeverity {
+		return
+	}
+
+	timestamp := getTimestamp()
+	lineNumber, fileName, methodName := getCallerStack()
+	formattedString := fmt.Sprintf(format, a...)
+
+	row := new(jsonLogRow)
+	row.Timestamp = timestamp
+	row.FileName = fileName
+	row.LineNumber = lineNumber
+	row.MethodName = methodName
+	row.Message = formattedString
+	row.Severity = severityToString(severity)
+	row.sevLevel = severity
+	row.format = l.CurrentLogFormat
+	row.Suppressed = suppressed
+	if event != nil {
+		if l.StringifyEvent {
+			if e, err := json.Marshal(event); err == nil {
+				row.Event = string(e)
+			}
+		} else {
+			row.Event = event
+		}
+	}
+	if len(l.fields) > 0 {
+		row.Fields = l.fields
+	}
+
+	var span trace.Span
+	if ctx != nil {
+		span = trace.SpanFromContext(ctx)
+		if sc := span.SpanContext(); sc.IsValid() {
+			row.TraceId = sc.TraceID().String()
+			row.SpanId = sc.SpanID().String()
+			row.TraceFlags = sc.TraceFlags().String()
+
+			if severity == LogError || severity == LogFatal {
+				span.RecordError(fmt.Errorf("%s", formattedString))
+				span.SetStatus(codes.Error, formattedString)
+			}
+		}
+	}
+
+	dispatchToSinks(row)
+	publishToSubscribers(row)
+}
+
+func (l *Logger) FatalCtx(ctx context.Context, format string, a ...interface{}) {
+	l.logCtx(ctx, LogFatal, nil, 0, format, a...)
+	drainSinks()
+	os.Exit(255)
+}
+
+func (l *Logger) ErrorCtx(ctx context.Context, format string, a ...interface{}) {
+	l.logCtx(ctx, LogError, nil, 0, format, a...)
+}
+
+func (l *Logger) InfoCtx(ctx context.Context, format string, a ...interface{}) {
+	l.logCtx(ctx, LogInfo, nil, 0, format, a...)
+}
+
+func (l *Logger) WarnCtx(ctx context.Context, format string, a ...interface{}) {
+	l.logCtx(ctx, LogWarning, nil, 0, format, a...)
+}
+
+func (l *Logger) DebugCtx(ctx context.Context, format string, a ...interface{}) {
+	l.logCtx(ctx, LogDebug, nil, 0, format, a...)
+}
+
+func (l *Logger) FatalCtxEvent(ctx context.Context, event interface{}, format string, a ...interface{}) {
+	l.logCtx(ctx, LogFatal, event, 0, format, a...)
+	drainSinks()
+	os.Exit(255)
+}
+
+func (l *Logger) ErrorCtxEvent(ctx context.Context, event interface{}, format string, a ...interface{}) {
+	l.logCtx(ctx, LogError, event, 0, format, a...)
+}
+
+func (l *Logger) InfoCtxEvent(ctx context.Context, event interface{}, format string, a ...interface{}) {
+	l.logCtx(ctx, LogInfo, event, 0, format, a...)
+}
+
+func (l *Logger) WarnCtxEvent(ctx context.Context, event interface{}, format string, a ...interface{}) {
+	l.logCtx(ctx, LogWarning, event, 0, format, a...)
+}
+
+func (l *Logger) DebugCtxEvent(ctx context.Context, event interface{}, format string, a ...interface{}) {
+	l.logCtx(ctx, LogDebug, event, 0, format, a...)
+}
+
+func (l *Logger) FatalCtxEventId(ctx context.Context, eventId string, event interface{}, format string, a ...interface{}) {
+	if allowed, suppressed := evaluateSampling(eventId); allowed {
+		l.logCtx(ctx, LogFatal, eventToEventId(eventId, event), suppressed, format, a...)
+	}
+	drainSinks()
+	os.Exit(255)
+}
+
+func (l *Logger) ErrorCtxEventId(ctx context.Context, eventId string, event interface{}, format string, a ...interface{}) {
+	if allowed, suppressed := evaluateSampling(eventId); allowed {
+		l.logCtx(ctx, LogError, eventToEventId(eventId, event), suppressed, format, a...)
+	}
+}
+
+func (l *Logger) InfoCtxEventId(ctx context.Context, eventId string, event interface{}, format string, a ...interface{}) {
+	if allowed, suppressed := evaluateSampling(eventId); allowed {
+		l.logCtx(ctx, LogInfo, eventToEventId(eventId, event), suppressed, format, a...)
+	}
+}
+
+func (l *Logger) WarnCtxEventId(ctx context.Context, eventId string, event interface{}, format string, a ...interface{}) {
+	if allowed, suppressed := evaluateSampling(eventId); allowed {
+		l.logCtx(ctx, LogWarning, eventToEventId(eventId, event), suppressed, format, a...)
+	}
+}
+
+func (l *Logger) DebugCtxEventId(ctx context.Context, eventId string, event interface{}, format string, a ...interface{}) {
+	if allowed, suppressed := evaluateSampling(eventId); allowed {
+		l.logCtx(ctx, LogDebug, eventToEventId(eventId, event), suppressed, format, a...)
+	}
+}
+
+// FatalCtx logs at fatal severity on the default Logger, attaching any trace
+// context found in ctx, then exits the process.
+func FatalCtx(ctx context.Context, format string, a ...interface{}) {
+	defaultLogger.logCtx(ctx, LogFatal, nil, 0, format, a...)
+	drainSinks()
+	os.Exit(255)
+}
+
+func ErrorCtx(ctx context.Context, format string, a ...interface{}) {
+	defaultLogger.logCtx(ctx, LogError, nil, 0, format, a...)
+}
+
+func InfoCtx(ctx context.Context, format string, a ...interface{}) {
+	defaultLogger.logCtx(ctx, LogInfo, nil, 0, format, a...)
+}
+
+func WarningCtx(ctx context.Context, format string, a ...interface{}) {
+	defaultLogger.logCtx(ctx, LogWarning, nil, 0, format, a...)
+}
+
+func DebugCtx(ctx context.Context, format string, a ...interface{}) {
+	defaultLogger.logCtx(ctx, LogDebug, nil, 0, format, a...)
+}
+
+func FatalCtxEvent(ctx context.Context, event interface{}, format string, a ...interface{}) {
+	defaultLogger.logCtx(ctx, LogFatal, event, 0, format, a...)
+	drainSinks()
+	os.Exit(255)
+}
+
+func ErrorCtxEvent(ctx context.Context, event interface{}, format string, a ...interface{}) {
+	defaultLogger.logCtx(ctx, LogError, event, 0, format, a...)
+}
+
+func InfoCtxEvent(ctx context.Context, event interface{}, format string, a ...interface{}) {
+	defaultLogger.logCtx(ctx, LogInfo, event, 0, format, a...)
+}
+
+func WarningCtxEvent(ctx context.Context, event interface{}, format string, a ...interface{}) {
+	defaultLogger.logCtx(ctx, LogWarning, event, 0, format, a...)
+}
+
+func DebugCtxEvent(ctx context.Context, event interface{}, format string, a ...interface{}) {
+	defaultLogger.logCtx(ctx, LogDebug, event, 0, format, a...)
+}
+
+func FatalCtxEventId(ctx context.Context, eventId string, event interface{}, format string, a ...interface{}) {
+	if allowed, suppressed := evaluateSampling(eventId); allowed {
+		defaultLogger.logCtx(ctx, LogFatal, eventToEventId(eventId, event), suppressed, format, a...)
+	}
+	drainSinks()
+	os.Exit(255)
+}
+
+func ErrorCtxEventId(ctx context.Context, eventId string, event interface{}, format string, a ...interface{}) {
+	if allowed, suppressed := evaluateSampling(eventId); allowed {
+		defaultLogger.logCtx(ctx, LogError, eventToEventId(eventId, event), suppressed, format, a...)
+	}
+}
+
+func InfoCtxEventId(ctx context.Context, eventId string, event interface{}, format string, a ...interface{}) {
+	if allowed, suppressed := evaluateSampling(eventId); allowed {
+		defaultLogger.logCtx(ctx, LogInfo, eventToEventId(eventId, event), suppressed, format, a...)
+	}
+}
+
+func WarningCtxEventId(ctx context.Context, eventId string, event interface{}, format string, a ...interface{}) {
+	if allowed, suppressed := evaluateSampling(eventId); allowed {
+		defaultLogger.logCtx(ctx, LogWarning, eventToEventId(eventId, event), suppressed, format, a...)
+	}
+}
+
+func DebugCtxEventId(ctx context.Context, eventId string, event interface{}, format string, a ...interface{}) {
+	if allowed, suppressed := evaluateSampling(eventId); allowed {
+		defaultLogger.logCtx(ctx, LogDebug, eventToEventId(eventId, event), suppressed, format, a...)
+	}
+}
@@ -0,0 +1,129 @@
+package logger
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"sync/atomic"
+)
+
+// logTraceEnvVar lists the facilities whose debug output should be enabled
+// at startup: a comma-separated list of facility names, or "all".
+const logTraceEnvVar = "LOGTRACE"
+
+var facilityRegistry = NewConcurrentMap()
+
+var (
+	traceAllFacilities bool
+	tracedFacilities   = make(map[string]bool)
+)
+
+func init() {
+	spec := os.Getenv(logTraceEnvVar)
+	if spec == "" {
+		return
+	}
+	for _, name := range strings.Split(spec, ",") {
+		name = strings.TrimSpace(name)
+		switch {
+		case name == "":
+			continue
+		case name == "all":
+			traceAllFacilities = true
+		default:
+			tracedFacilities[name] = true
+		}
+	}
+}
+
+// Facility is a named subsystem whose debug output can be switched on
+// independently of the global CurrentLogSeverity, typically via the
+// LOGTRACE environment variable.
+type Facility struct {
+	name    string
+	enabled int32
+}
+
+// NewFacility returns the Facility registered under name, creating it (and
+// deciding its initial enabled state from LOGTRACE) if it doesn't exist yet.
+func NewFacility(name string) *Facility {
+	if v, ok := facilityRegistry.Get(name); ok {
+		return v.(*Facility)
+	}
+
+	f := &Facility{name: name}
+	if traceAllFacilities || tracedFacilities[name] {
+		f.enabled = 1
+	}
+	if !facilityRegistry.SetIfAbsent(name, f) {
+		v, _ := facilityRegistry.Get(name)
+		return v.(*Facility)
+	}
+	return f
+}
+
+// SetFacility enables or disables debug output for the named facility,
+// creating it first if necessary.
+func SetFacility(name string, enabled bool) {
+	NewFacility(name).setEnabled(enabled)
+}
+
+func (f *Facility) setEnabled(enabled bool) {
+	v := int32(0)
+	if enabled {
+		v = 1
+	}
+	atomic.StoreInt32(&f.enabled, v)
+}
+
+func (f *Facility) isEnabled() bool {
+	return atomic.LoadInt32(&f.enabled) == 1
+}
+
+// Debug logs at debug severity whenever the facility is enabled, regardless
+// of the global CurrentLogSeverity.
+func (f *Facility) Debug(format string, a ...interface{}) {
+	if !f.isEnabled() {
+		return
+	}
+	logFacilitySeverity(LogDebug, f.name, format, a...)
+}
+
+// Info logs at info severity, subject to the global CurrentLogSeverity.
+func (f *Facility) Info(format string, a ...interface{}) {
+	logFacilitySeverity(LogInfo, f.name, format, a...)
+}
+
+// Warning logs at warning severity, subject to the global CurrentLogSeverity.
+func (f *Facility) Warning(format string, a ...interface{}) {
+	logFacilitySeverity(LogWarning, f.name, format, a...)
+}
+
+// Error logs at error severity, subject to the global CurrentLogSeverity.
+func (f *Facility) Error(format string, a ...interface{}) {
+	logFacilitySeverity(LogError, f.name, format, a...)
+}
+
+func logFacilitySeverity(severity int, facility string, format string, a ...interface{}) {
+	if severity != LogDebug && severity > defaultLogger.CurrentLogSeverity {
+		return
+	}
+
+	timestamp := getTimestamp()
+	lineNumber, fileName, methodName := getCallerStack()
+	formattedString := fmt.Sprintf(format, a...)
+
+	row := new(jsonLogRow)
+	row.Timestamp = timestamp
+	row.FileName = fileName
+	row.LineNumber = lineNumber
+	row.MethodName = methodName
+	row.Message = formattedString
+	row.Severity = severityToString(severity)
+	row.Facility = facility
+	row.sevLevel = severity
+	row.format = defaultLogger.CurrentLogFormat
+
+	dispatchToSinks(row)
+	publishToSubscribers(row)
+}
@@ -0,0 +1,185 @@
+package logger
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+// Option configures a Logger returned by New.
+type Option func(*Logger)
+
+// WithSeverity sets the Logger's initial minimum severity (LogFatal..LogDebug).
+func WithSeverity(severity int) Option {
+	return func(l *Logger) { l.CurrentLogSeverity = severity }
+}
+
+// WithFormat sets the Logger's initial output format: LogTextFormat or LogJsonFormat.
+func WithFormat(format int) Option {
+	return func(l *Logger) { l.CurrentLogFormat = format }
+}
+
+// WithStringifyEvent sets whether events are JSON-encoded to a string before
+// being attached to a row, rather than attached as-is.
+func WithStringifyEvent(stringify bool) Option {
+	return func(l *Logger) { l.StringifyEvent = stringify }
+}
+
+// Logger is a configurable logger instance. The package-level functions
+// (Debug, Info, Error, ...) are thin wrappers around defaultLogger; use New
+// to create independent instances, and With to bind context fields to one.
+type Logger struct {
+	CurrentLogSeverity int
+	CurrentLogFormat   int
+	StringifyEvent     bool
+
+	fields map[string]interface{}
+}
+
+// defaultLogger backs the package-level functions; SetLogSeverity,
+// SetLogFormat and StringifyEvent configure it directly.
+var defaultLogger = New()
+
+// New returns a Logger configured by opts, defaulting to info severity, text
+// format and event stringification, matching the package defaults.
+func New(opts ...Option) *Logger {
+	l := &Logger{
+		CurrentLogSeverity: LogInfo,
+		CurrentLogFormat:   LogTextFormat,
+		StringifyEvent:     true,
+	}
+	for _, opt := range opts {
+		opt(l)
+	}
+	return l
+}
+
+// With returns a child Logger whose fields are merged into every JSON row it
+// emits, under "fields". l itself is left untouched: a copy-on-write
+// snapshot of the merged fields backs the child, so With is cheap and safe
+// to call concurrently from multiple goroutines sharing l.
+func (l *Logger) With(fields map[string]interface{}) *Logger {
+	merged := make(map[string]interface{}, len(l.fields)+len(fields))
+	for k, v := range l.fields {
+		merged[k] = v
+	}
+	for k, v := range fields {
+		merged[k] = v
+	}
+
+	child := *l
+	child.fields = merged
+	return &child
+}
+
+func (l *Logger) log(severity int, event interface{}, suppressed uint64, format string, a ...interface{}) {
+	if severity > l.CurrentLogSeverity {
+		return
+	}
+
+	timestamp := getTimestamp()
+	lineNumber, fileName, methodName := getCallerStack()
+	formattedString := fmt.Sprintf(format, a...)
+
+	row := new(jsonLogRow)
+	row.Timestamp = timestamp
+	row.FileName = fileName
+	row.LineNumber = lineNumber
+	row.MethodName = methodName
+	row.Message = formattedString
+	row.Severity = severityToString(severity)
+	row.sevLevel = severity
+	row.format = l.CurrentLogFormat
+	row.Suppressed = suppressed
+	if event != nil {
+		if l.StringifyEvent {
+			if e, err := json.Marshal(event); err == nil {
+				row.Event = string(e)
+			}
+		} else {
+			row.Event = event
+		}
+	}
+	if len(l.fields) > 0 {
+		row.Fields = l.fields
+	}
+
+	dispatchToSinks(row)
+	publishToSubscribers(row)
+}
+
+func (l *Logger) Fatal(format string, a ...interface{}) {
+	l.log(LogFatal, nil, 0, format, a...)
+	drainSinks()
+	os.Exit(255)
+}
+
+func (l *Logger) Error(format string, a ...interface{}) {
+	l.log(LogError, nil, 0, format, a...)
+}
+
+func (l *Logger) Info(format string, a ...interface{}) {
+	l.log(LogInfo, nil, 0, format, a...)
+}
+
+func (l *Logger) Warn(format string, a ...interface{}) {
+	l.log(LogWarning, nil, 0, format, a...)
+}
+
+func (l *Logger) Debug(format string, a ...interface{}) {
+	l.log(LogDebug, nil, 0, format, a...)
+}
+
+func (l *Logger) FatalEvent(event interface{}, format string, a ...interface{}) {
+	l.log(LogFatal, event, 0, format, a...)
+	drainSinks()
+	os.Exit(255)
+}
+
+func (l *Logger) ErrorEvent(event interface{}, format string, a ...interface{}) {
+	l.log(LogError, event, 0, format, a...)
+}
+
+func (l *Logger) InfoEvent(event interface{}, format string, a ...interface{}) {
+	l.log(LogInfo, event, 0, format, a...)
+}
+
+func (l *Logger) WarnEvent(event interface{}, format string, a ...interface{}) {
+	l.log(LogWarning, event, 0, format, a...)
+}
+
+func (l *Logger) DebugEvent(event interface{}, format string, a ...interface{}) {
+	l.log(LogDebug, event, 0, format, a...)
+}
+
+func (l *Logger) FatalEventId(eventId string, event interface{}, format string, a ...interface{}) {
+	if allowed, suppressed := evaluateSampling(eventId); allowed {
+		l.log(LogFatal, eventToEventId(eventId, event), suppressed, format, a...)
+	}
+	drainSinks()
+	os.Exit(255)
+}
+
+func (l *Logger) ErrorEventId(eventId string, event interface{}, format string, a ...interface{}) {
+	if allowed, suppressed := evaluateSampling(eventId); allowed {
+		l.log(LogError, eventToEventId(eventId, event), suppressed, format, a...)
+	}
+}
+
+func (l *Logger) InfoEventId(eventId string, event interface{}, format string, a ...interface{}) {
+	if allowed, suppressed := evaluateSampling(eventId); allowed {
+		l.log(LogInfo, eventToEventId(eventId, event), suppressed, format, a...)
+	}
+}
+
+func (l *Logger) WarnEventId(eventId string, event interface{}, format string, a ...interface{}) {
+	if allowed, suppressed := evaluateSampling(eventId); allowed {
+		l.log(LogWarning, eventToEventId(eventId, event), suppressed, format, a...)
+	}
+}
+
+func (l *Logger) DebugEventId(eventId string, event interface{}, format string, a ...interface{}) {
+	if allowed, suppressed := evaluateSampling(eventId); allowed {
+		l.log(LogDebug, eventToEventId(eventId, event), suppressed, format, a...)
+	}
+}
@@ -1,9 +1,6 @@
 package logger
 
 import (
-	"encoding/json"
-	"fmt"
-	"log"
 	"os"
 	"runtime"
 	"strings"
@@ -36,42 +33,48 @@ const (
 	RFC3339Milli = "2006-01-02T15:04:05.000Z07:00"
 )
 
-type logWrapper struct {
-	CurrentLogSeverity int
-	CurrentLogFormat   int
-	StringifyEvent     bool
-}
-
-var lwInst = &logWrapper{
-	CurrentLogSeverity: LogInfo,
-	CurrentLogFormat:   LogTextFormat,
-	StringifyEvent:     true,
-}
-
 //var AppName string
 
-//SetLogSeverity sets global log wrapper severity
+//SetLogSeverity sets the default logger's severity
 func SetLogSeverity(severity int) {
-	lwInst.CurrentLogSeverity = severity
+	defaultLogger.CurrentLogSeverity = severity
 }
 
-//SetLogFormat sets the log output format; use LogTextFormat or LogJsonFormat
+//SetLogFormat sets the default logger's output format; use LogTextFormat or LogJsonFormat
 func SetLogFormat(fmt int) {
-	lwInst.CurrentLogFormat = fmt
+	defaultLogger.CurrentLogFormat = fmt
 }
 
 func StringifyEvent(s bool) {
-	lwInst.StringifyEvent = s
+	defaultLogger.StringifyEvent = s
 }
 
 type jsonLogRow struct {
-	Timestamp  string      `json:"timestamp"`
-	FileName   string      `json:"file"`
-	LineNumber int         `json:"line"`
-	MethodName string      `json:"method"`
-	Message    string      `json:"message"`
-	Event      interface{} `json:"event,omitempty"`
-	Severity   string      `json:"severity"`
+	Timestamp  string                 `json:"timestamp"`
+	FileName   string                 `json:"file"`
+	LineNumber int                    `json:"line"`
+	MethodName string                 `json:"method"`
+	Message    string                 `json:"message"`
+	Event      interface{}            `json:"event,omitempty"`
+	Severity   string                 `json:"severity"`
+	Facility   string                 `json:"facility,omitempty"`
+	Fields     map[string]interface{} `json:"fields,omitempty"`
+	Suppressed uint64                 `json:"suppressed,omitempty"`
+	TraceId    string                 `json:"trace_id,omitempty"`
+	SpanId     string                 `json:"span_id,omitempty"`
+	TraceFlags string                 `json:"trace_flags,omitempty"`
+
+	// sevLevel is the numeric severity (LogFatal..LogDebug) the row was
+	// logged at; it drives per-sink filtering and is never serialized.
+	sevLevel int
+
+	// format is the originating Logger's CurrentLogFormat; StderrSink reads
+	// it instead of defaultLogger's so per-instance formatting actually works.
+	format int
+
+	// barrier, when set, marks this row as a drainSinks marker rather than
+	// real log data: the sink worker closes it instead of calling Write.
+	barrier chan struct{}
 }
 
 //var defaultLocation, _ = time.LoadLocation("Europe/Rome")
@@ -102,100 +105,81 @@ func severityToString(severity int) string {
 	return out
 }
 
+// Fatal logs at fatal severity on the default Logger, then exits the process.
 func Fatal(format string, a ...interface{}) {
-	logWithSeverity(LogFatal, nil, format, a...)
+	defaultLogger.log(LogFatal, nil, 0, format, a...)
+	drainSinks()
 	os.Exit(255)
 }
 
 func Error(format string, a ...interface{}) {
-	logWithSeverity(LogError, nil, format, a...)
+	defaultLogger.log(LogError, nil, 0, format, a...)
 }
 
 func Info(format string, a ...interface{}) {
-	logWithSeverity(LogInfo, nil, format, a...)
+	defaultLogger.log(LogInfo, nil, 0, format, a...)
 }
 
 func Warning(format string, a ...interface{}) {
-	logWithSeverity(LogWarning, nil, format, a...)
+	defaultLogger.log(LogWarning, nil, 0, format, a...)
 }
 
 func Debug(format string, a ...interface{}) {
-	logWithSeverity(LogDebug, nil, format, a...)
+	defaultLogger.log(LogDebug, nil, 0, format, a...)
 }
 
 func FatalEvenId(eventId string, event interface{}, format string, a ...interface{}) {
-	logWithSeverity(LogFatal, eventToEventId(eventId, event), format, a...)
+	if allowed, suppressed := evaluateSampling(eventId); allowed {
+		defaultLogger.log(LogFatal, eventToEventId(eventId, event), suppressed, format, a...)
+	}
+	drainSinks()
 	os.Exit(255)
 }
 
 func ErrorEventId(eventId string, event interface{}, format string, a ...interface{}) {
-	logWithSeverity(LogError, eventToEventId(eventId, event), format, a...)
+	if allowed, suppressed := evaluateSampling(eventId); allowed {
+		defaultLogger.log(LogError, eventToEventId(eventId, event), suppressed, format, a...)
+	}
 }
 
 func InfoEventId(eventId string, event interface{}, format string, a ...interface{}) {
-	logWithSeverity(LogInfo, eventToEventId(eventId, event), format, a...)
+	if allowed, suppressed := evaluateSampling(eventId); allowed {
+		defaultLogger.log(LogInfo, eventToEventId(eventId, event), suppressed, format, a...)
+	}
 }
 
 func WarningEventId(eventId string, event interface{}, format string, a ...interface{}) {
-	logWithSeverity(LogWarning, eventToEventId(eventId, event), format, a...)
+	if allowed, suppressed := evaluateSampling(eventId); allowed {
+		defaultLogger.log(LogWarning, eventToEventId(eventId, event), suppressed, format, a...)
+	}
 }
 
 func DebugEventId(eventId string, event interface{}, format string, a ...interface{}) {
-	logWithSeverity(LogDebug, eventToEventId(eventId, event), format, a...)
+	if allowed, suppressed := evaluateSampling(eventId); allowed {
+		defaultLogger.log(LogDebug, eventToEventId(eventId, event), suppressed, format, a...)
+	}
 }
 
 func FatalEvent(event interface{}, format string, a ...interface{}) {
-	logWithSeverity(LogFatal, event, format, a...)
+	defaultLogger.log(LogFatal, event, 0, format, a...)
+	drainSinks()
 	os.Exit(255)
 }
 
 func ErrorEvent(event interface{}, format string, a ...interface{}) {
-	logWithSeverity(LogError, event, format, a...)
+	defaultLogger.log(LogError, event, 0, format, a...)
 }
 
 func InfoEvent(event interface{}, format string, a ...interface{}) {
-	logWithSeverity(LogInfo, event, format, a...)
+	defaultLogger.log(LogInfo, event, 0, format, a...)
 }
 
 func WarningEvent(event interface{}, format string, a ...interface{}) {
-	logWithSeverity(LogWarning, event, format, a...)
+	defaultLogger.log(LogWarning, event, 0, format, a...)
 }
 
 func DebugEvent(event interface{}, format string, a ...interface{}) {
-	logWithSeverity(LogDebug, event, format, a...)
-}
-
-func logWithSeverity(severity int, event interface{}, format string, a ...interface{}) {
-	timestamp := getTimestamp()
-	lineNumber, fileName, methodName := getCallerStack()
-	formattedString := fmt.Sprintf(format, a...)
-	log.SetFlags(0)
-	if severity <= lwInst.CurrentLogSeverity {
-		if lwInst.CurrentLogFormat == LogTextFormat {
-			wholeRow := fmt.Sprintf("[%v][%v][%v][%d] %v", timestamp, severityToString(severity), fileName, lineNumber, formattedString)
-			log.Println(wholeRow)
-		} else {
-			jsonRow := new(jsonLogRow)
-			jsonRow.Timestamp = timestamp
-			jsonRow.FileName = fileName
-			jsonRow.LineNumber = lineNumber
-			jsonRow.MethodName = methodName
-			if event != nil {
-				if lwInst.StringifyEvent {
-					if e, err := json.Marshal(event); err == nil {
-						jsonRow.Event = string(e)
-					}
-				} else {
-					jsonRow.Event = event
-				}
-			}
-			jsonRow.Message = formattedString
-			jsonRow.Severity = severityToString(severity)
-			if bv, err := json.Marshal(jsonRow); err == nil {
-				log.Println(string(bv))
-			}
-		}
-	}
+	defaultLogger.log(LogDebug, event, 0, format, a...)
 }
 
 func getTimestamp() string {
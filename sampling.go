@@ -0,0 +1,118 @@
+package logger
+
+import "time"
+
+// SamplingStrategy selects how a SamplingPolicy decides whether to let an
+// event through.
+type SamplingStrategy int
+
+const (
+	// SampleFirstNPerWindow lets through the first N occurrences within
+	// Window, then suppresses the rest until the window rolls over.
+	SampleFirstNPerWindow SamplingStrategy = iota
+	// SampleOneInN lets through every Nth occurrence.
+	SampleOneInN
+	// SampleExponentialBackoff lets through the 1st, 2nd, 4th, 8th, ...
+	// occurrence.
+	SampleExponentialBackoff
+)
+
+// SamplingPolicy configures how SetSampling throttles a given event ID.
+type SamplingPolicy struct {
+	Strategy SamplingStrategy
+
+	// N is the per-window allowance for SampleFirstNPerWindow, or the
+	// divisor for SampleOneInN. Unused by SampleExponentialBackoff.
+	N int
+
+	// Window is the rolling window used by SampleFirstNPerWindow.
+	Window time.Duration
+}
+
+type samplingState struct {
+	policy SamplingPolicy
+
+	count      uint64
+	suppressed uint64
+	windowFrom time.Time
+	nextEmit   uint64
+
+	allow              bool
+	suppressedThisEmit uint64
+}
+
+func (st *samplingState) decide(now time.Time) (bool, uint64) {
+	switch st.policy.Strategy {
+	case SampleOneInN:
+		n := st.policy.N
+		if n <= 0 {
+			n = 1
+		}
+		st.count++
+		if st.count%uint64(n) == 0 {
+			return st.emit()
+		}
+		st.suppressed++
+		return false, 0
+
+	case SampleExponentialBackoff:
+		if st.nextEmit == 0 {
+			st.nextEmit = 1
+		}
+		st.count++
+		if st.count == st.nextEmit {
+			st.nextEmit *= 2
+			return st.emit()
+		}
+		st.suppressed++
+		return false, 0
+
+	default: // SampleFirstNPerWindow
+		if st.windowFrom.IsZero() || now.Sub(st.windowFrom) >= st.policy.Window {
+			st.windowFrom = now
+			st.count = 0
+		}
+		st.count++
+		if int(st.count) <= st.policy.N {
+			return st.emit()
+		}
+		st.suppressed++
+		return false, 0
+	}
+}
+
+func (st *samplingState) emit() (bool, uint64) {
+	suppressed := st.suppressed
+	st.suppressed = 0
+	return true, suppressed
+}
+
+var samplingRegistry = NewConcurrentMap()
+
+// SetSampling installs policy for eventId; subsequent *EventId calls made
+// with that ID are throttled according to it until SetSampling is called
+// again for the same ID.
+func SetSampling(eventId string, policy SamplingPolicy) {
+	samplingRegistry.Set(eventId, &samplingState{policy: policy})
+}
+
+// evaluateSampling reports whether the event logged under eventId should be
+// let through, and how many prior occurrences were suppressed since the
+// last one that was. IDs with no installed policy always pass through.
+func evaluateSampling(eventId string) (bool, uint64) {
+	if !samplingRegistry.Has(eventId) {
+		return true, 0
+	}
+
+	res := samplingRegistry.UpdateOrInsert(eventId, nil, func(exist bool, valueInMap interface{}, _ interface{}) interface{} {
+		st, ok := valueInMap.(*samplingState)
+		if !ok {
+			st = &samplingState{}
+		}
+		st.allow, st.suppressedThisEmit = st.decide(time.Now())
+		return st
+	})
+
+	st := res.(*samplingState)
+	return st.allow, st.suppressedThisEmit
+}
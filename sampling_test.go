@@ -0,0 +1,95 @@
+package logger
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSampleOneInNPassesOnNthOccurrence(t *testing.T) {
+	st := &samplingState{policy: SamplingPolicy{Strategy: SampleOneInN, N: 3}}
+
+	var got []bool
+	for i := 0; i < 6; i++ {
+		allow, _ := st.decide(time.Now())
+		got = append(got, allow)
+	}
+
+	want := []bool{false, false, true, false, false, true}
+	for i, w := range want {
+		if got[i] != w {
+			t.Fatalf("call %d: got allow=%v, want %v (full sequence %v)", i+1, got[i], w, got)
+		}
+	}
+}
+
+func TestSampleOneInNReportsSuppressedCount(t *testing.T) {
+	st := &samplingState{policy: SamplingPolicy{Strategy: SampleOneInN, N: 3}}
+
+	st.decide(time.Now())
+	st.decide(time.Now())
+	allow, suppressed := st.decide(time.Now())
+	if !allow || suppressed != 2 {
+		t.Fatalf("3rd call: got allow=%v suppressed=%d, want true, 2", allow, suppressed)
+	}
+}
+
+func TestSampleFirstNPerWindow(t *testing.T) {
+	st := &samplingState{policy: SamplingPolicy{Strategy: SampleFirstNPerWindow, N: 2, Window: 20 * time.Millisecond}}
+	base := time.Now()
+
+	if allow, _ := st.decide(base); !allow {
+		t.Fatal("1st occurrence in window: want allowed")
+	}
+	if allow, _ := st.decide(base); !allow {
+		t.Fatal("2nd occurrence in window: want allowed")
+	}
+	if allow, _ := st.decide(base); allow {
+		t.Fatal("3rd occurrence in window: want suppressed")
+	}
+
+	allow, suppressed := st.decide(base.Add(25 * time.Millisecond))
+	if !allow || suppressed != 1 {
+		t.Fatalf("1st occurrence after rollover: got allow=%v suppressed=%d, want true, 1", allow, suppressed)
+	}
+}
+
+func TestSampleExponentialBackoff(t *testing.T) {
+	st := &samplingState{policy: SamplingPolicy{Strategy: SampleExponentialBackoff}}
+
+	var allowedAt []int
+	for i := 1; i <= 8; i++ {
+		if allow, _ := st.decide(time.Now()); allow {
+			allowedAt = append(allowedAt, i)
+		}
+	}
+
+	want := []int{1, 2, 4, 8}
+	if len(allowedAt) != len(want) {
+		t.Fatalf("got allowed occurrences %v, want %v", allowedAt, want)
+	}
+	for i, w := range want {
+		if allowedAt[i] != w {
+			t.Fatalf("got allowed occurrences %v, want %v", allowedAt, want)
+		}
+	}
+}
+
+func TestEvaluateSamplingPassesThroughUnregisteredEventId(t *testing.T) {
+	allow, suppressed := evaluateSampling("no-such-policy-registered")
+	if !allow || suppressed != 0 {
+		t.Fatalf("got allow=%v suppressed=%d, want true, 0", allow, suppressed)
+	}
+}
+
+func TestSetSamplingThrottlesRegisteredEventId(t *testing.T) {
+	SetSampling("test-throttled-event", SamplingPolicy{Strategy: SampleOneInN, N: 2})
+
+	first, _ := evaluateSampling("test-throttled-event")
+	second, _ := evaluateSampling("test-throttled-event")
+	if first {
+		t.Fatal("1st call: want suppressed")
+	}
+	if !second {
+		t.Fatal("2nd call: want allowed")
+	}
+}
@@ -0,0 +1,177 @@
+package logger
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+)
+
+// Sink receives already-formatted log rows and delivers them to a destination
+// (stderr, a file, syslog, a remote collector, ...). Write is called from a
+// dedicated per-sink worker goroutine, so implementations do not need to be
+// safe for concurrent use by multiple callers at once.
+type Sink interface {
+	Write(row *jsonLogRow) error
+	Close() error
+}
+
+// sinkQueueSize bounds how many rows a sink may have pending before
+// dispatchToSinks starts dropping rows for it instead of blocking the caller.
+const sinkQueueSize = 1024
+
+type sinkEntry struct {
+	sink     Sink
+	severity int32
+	dropped  uint64
+	queue    chan *jsonLogRow
+	wg       sync.WaitGroup
+}
+
+func newSinkEntry(s Sink, severity int) *sinkEntry {
+	e := &sinkEntry{
+		sink:     s,
+		severity: int32(severity),
+		queue:    make(chan *jsonLogRow, sinkQueueSize),
+	}
+	e.wg.Add(1)
+	go e.run()
+	return e
+}
+
+func (e *sinkEntry) run() {
+	defer e.wg.Done()
+	for row := range e.queue {
+		if row.barrier != nil {
+			close(row.barrier)
+			continue
+		}
+		_ = e.sink.Write(row)
+	}
+}
+
+// drain blocks until every row enqueued before this call has been written,
+// by enqueueing a barrier marker behind them and waiting for the worker to
+// reach it.
+func (e *sinkEntry) drain() {
+	barrier := make(chan struct{})
+	e.queue <- &jsonLogRow{barrier: barrier}
+	<-barrier
+}
+
+func (e *sinkEntry) level() int {
+	return int(atomic.LoadInt32(&e.severity))
+}
+
+func (e *sinkEntry) setLevel(severity int) {
+	atomic.StoreInt32(&e.severity, int32(severity))
+}
+
+func (e *sinkEntry) dispatch(row *jsonLogRow) {
+	if row.sevLevel > e.level() {
+		return
+	}
+	select {
+	case e.queue <- row:
+	default:
+		atomic.AddUint64(&e.dropped, 1)
+	}
+}
+
+var sinkRegistry = struct {
+	sync.RWMutex
+	entries map[string]*sinkEntry
+}{entries: make(map[string]*sinkEntry)}
+
+func init() {
+	_ = RegisterSink("stderr", NewStderrSink())
+}
+
+// RegisterSink adds s to the set of sinks that every logged row is fanned out
+// to, under name. The sink receives all severities until SetLevelForSink
+// narrows it down. It is an error to register a name that is already in use.
+func RegisterSink(name string, s Sink) error {
+	sinkRegistry.Lock()
+	defer sinkRegistry.Unlock()
+	if _, exists := sinkRegistry.entries[name]; exists {
+		return fmt.Errorf("logger: sink %q is already registered", name)
+	}
+	sinkRegistry.entries[name] = newSinkEntry(s, LogDebug)
+	return nil
+}
+
+// SetLevelForSink restricts the sink registered under name to rows at
+// severity or more severe (using the LogFatal..LogDebug ordering).
+func SetLevelForSink(name string, severity int) error {
+	sinkRegistry.RLock()
+	e, ok := sinkRegistry.entries[name]
+	sinkRegistry.RUnlock()
+	if !ok {
+		return fmt.Errorf("logger: sink %q is not registered", name)
+	}
+	e.setLevel(severity)
+	return nil
+}
+
+// RemoveSink stops and closes the sink registered under name.
+func RemoveSink(name string) error {
+	sinkRegistry.Lock()
+	e, ok := sinkRegistry.entries[name]
+	if ok {
+		delete(sinkRegistry.entries, name)
+	}
+	sinkRegistry.Unlock()
+	if !ok {
+		return fmt.Errorf("logger: sink %q is not registered", name)
+	}
+	close(e.queue)
+	e.wg.Wait()
+	return e.sink.Close()
+}
+
+// SinkStat reports the health of a single registered sink.
+type SinkStat struct {
+	Name    string
+	Dropped uint64
+	Queued  int
+}
+
+// SinkStats returns a point-in-time snapshot of every registered sink's
+// dropped-row counter and current queue depth.
+func SinkStats() []SinkStat {
+	sinkRegistry.RLock()
+	defer sinkRegistry.RUnlock()
+	stats := make([]SinkStat, 0, len(sinkRegistry.entries))
+	for name, e := range sinkRegistry.entries {
+		stats = append(stats, SinkStat{
+			Name:    name,
+			Dropped: atomic.LoadUint64(&e.dropped),
+			Queued:  len(e.queue),
+		})
+	}
+	return stats
+}
+
+func dispatchToSinks(row *jsonLogRow) {
+	sinkRegistry.RLock()
+	defer sinkRegistry.RUnlock()
+	for _, e := range sinkRegistry.entries {
+		e.dispatch(row)
+	}
+}
+
+// drainSinks blocks until every registered sink has written everything
+// dispatched to it so far. The Fatal* functions call this right before
+// exiting the process, since dispatch is asynchronous and os.Exit would
+// otherwise race ahead of the worker goroutines and swallow the crash line.
+func drainSinks() {
+	sinkRegistry.RLock()
+	entries := make([]*sinkEntry, 0, len(sinkRegistry.entries))
+	for _, e := range sinkRegistry.entries {
+		entries = append(entries, e)
+	}
+	sinkRegistry.RUnlock()
+
+	for _, e := range entries {
+		e.drain()
+	}
+}
@@ -0,0 +1,51 @@
+package logger
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// ElasticsearchSink batches rows and ships them to an Elasticsearch _bulk
+// endpoint, one index action per row. It reuses HTTPSink's batching, flush
+// timer and NDJSON transport.
+type ElasticsearchSink struct {
+	*HTTPSink
+	Index string
+}
+
+// NewElasticsearchSink returns an ElasticsearchSink that bulk-indexes into
+// index via the _bulk endpoint at url (e.g. "http://localhost:9200/_bulk"),
+// batching up to batchSize rows or flushInterval worth of accumulation time.
+func NewElasticsearchSink(url, index string, batchSize int, flushInterval time.Duration) *ElasticsearchSink {
+	return &ElasticsearchSink{
+		HTTPSink: NewHTTPSink(url, batchSize, flushInterval),
+		Index:    index,
+	}
+}
+
+func (s *ElasticsearchSink) Write(row *jsonLogRow) error {
+	actionLine, err := json.Marshal(map[string]interface{}{
+		"index": map[string]interface{}{"_index": s.Index},
+	})
+	if err != nil {
+		return err
+	}
+	rowLine, err := json.Marshal(row)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	s.buf.Write(actionLine)
+	s.buf.WriteByte('\n')
+	s.buf.Write(rowLine)
+	s.buf.WriteByte('\n')
+	s.count++
+	full := s.count >= s.BatchSize
+	s.mu.Unlock()
+
+	if full {
+		return s.flush()
+	}
+	return nil
+}
@@ -0,0 +1,99 @@
+package logger
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+)
+
+// FileSink writes rows as newline-delimited JSON to a file, rotating it once
+// it grows past MaxSizeBytes or MaxAge has elapsed since it was opened.
+type FileSink struct {
+	Path         string
+	MaxSizeBytes int64
+	MaxAge       time.Duration
+
+	mu       sync.Mutex
+	file     *os.File
+	size     int64
+	openedAt time.Time
+}
+
+// NewFileSink opens (creating if necessary) path and returns a FileSink that
+// rotates it once it grows past maxSizeBytes or maxAge has passed since it
+// was opened. A maxSizeBytes or maxAge of 0 disables that rotation trigger.
+func NewFileSink(path string, maxSizeBytes int64, maxAge time.Duration) (*FileSink, error) {
+	s := &FileSink{Path: path, MaxSizeBytes: maxSizeBytes, MaxAge: maxAge}
+	if err := s.open(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+func (s *FileSink) open() error {
+	f, err := os.OpenFile(s.Path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return err
+	}
+	info, err := f.Stat()
+	if err != nil {
+		_ = f.Close()
+		return err
+	}
+	s.file = f
+	s.size = info.Size()
+	s.openedAt = time.Now()
+	return nil
+}
+
+func (s *FileSink) needsRotation() bool {
+	if s.MaxSizeBytes > 0 && s.size >= s.MaxSizeBytes {
+		return true
+	}
+	if s.MaxAge > 0 && time.Since(s.openedAt) >= s.MaxAge {
+		return true
+	}
+	return false
+}
+
+func (s *FileSink) rotate() error {
+	if s.file != nil {
+		_ = s.file.Close()
+	}
+	rotated := fmt.Sprintf("%s.%s", s.Path, time.Now().Format("20060102T150405.000"))
+	if err := os.Rename(s.Path, rotated); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return s.open()
+}
+
+func (s *FileSink) Write(row *jsonLogRow) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.needsRotation() {
+		if err := s.rotate(); err != nil {
+			return err
+		}
+	}
+
+	bv, err := json.Marshal(row)
+	if err != nil {
+		return err
+	}
+	bv = append(bv, '\n')
+	n, err := s.file.Write(bv)
+	s.size += int64(n)
+	return err
+}
+
+func (s *FileSink) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.file == nil {
+		return nil
+	}
+	return s.file.Close()
+}
@@ -0,0 +1,107 @@
+package logger
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// HTTPSink buffers rows and POSTs them as a newline-delimited JSON batch to
+// URL whenever BatchSize rows have accumulated, and on every tick of a
+// FlushInterval ticker; a size-triggered flush does not reset the ticker.
+type HTTPSink struct {
+	URL           string
+	BatchSize     int
+	FlushInterval time.Duration
+	Client        *http.Client
+
+	mu    sync.Mutex
+	buf   bytes.Buffer
+	count int
+	done  chan struct{}
+	wg    sync.WaitGroup
+}
+
+// NewHTTPSink returns an HTTPSink that batches up to batchSize rows, or
+// flushInterval worth of accumulation time, before POSTing NDJSON to url.
+func NewHTTPSink(url string, batchSize int, flushInterval time.Duration) *HTTPSink {
+	s := &HTTPSink{
+		URL:           url,
+		BatchSize:     batchSize,
+		FlushInterval: flushInterval,
+		Client:        &http.Client{Timeout: 10 * time.Second},
+		done:          make(chan struct{}),
+	}
+	s.wg.Add(1)
+	go s.flushLoop()
+	return s
+}
+
+func (s *HTTPSink) flushLoop() {
+	defer s.wg.Done()
+
+	// A nil channel blocks forever in the select below, so a non-positive
+	// FlushInterval simply disables the timer-based flush and leaves
+	// BatchSize as the only trigger.
+	var tick <-chan time.Time
+	if s.FlushInterval > 0 {
+		ticker := time.NewTicker(s.FlushInterval)
+		defer ticker.Stop()
+		tick = ticker.C
+	}
+
+	for {
+		select {
+		case <-tick:
+			_ = s.flush()
+		case <-s.done:
+			_ = s.flush()
+			return
+		}
+	}
+}
+
+func (s *HTTPSink) Write(row *jsonLogRow) error {
+	bv, err := json.Marshal(row)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	s.buf.Write(bv)
+	s.buf.WriteByte('\n')
+	s.count++
+	full := s.count >= s.BatchSize
+	s.mu.Unlock()
+
+	if full {
+		return s.flush()
+	}
+	return nil
+}
+
+func (s *HTTPSink) flush() error {
+	s.mu.Lock()
+	if s.count == 0 {
+		s.mu.Unlock()
+		return nil
+	}
+	payload := append([]byte(nil), s.buf.Bytes()...)
+	s.buf.Reset()
+	s.count = 0
+	s.mu.Unlock()
+
+	resp, err := s.Client.Post(s.URL, "application/x-ndjson", bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	return resp.Body.Close()
+}
+
+func (s *HTTPSink) Close() error {
+	close(s.done)
+	s.wg.Wait()
+	return nil
+}
@@ -0,0 +1,41 @@
+package logger
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+)
+
+// StderrSink reproduces the logger's original behavior: rows are written
+// through the standard library log package, as text or as JSON depending on
+// the current log format.
+type StderrSink struct{}
+
+// NewStderrSink returns the default sink that the logger registers itself at
+// init time.
+func NewStderrSink() *StderrSink {
+	return &StderrSink{}
+}
+
+func (s *StderrSink) Write(row *jsonLogRow) error {
+	log.SetFlags(0)
+	if row.format == LogTextFormat {
+		wholeRow := fmt.Sprintf("[%v][%v][%v][%d] %v", row.Timestamp, row.Severity, row.FileName, row.LineNumber, row.Message)
+		if row.TraceId != "" {
+			wholeRow = fmt.Sprintf("%v trace_id=%v", wholeRow, row.TraceId)
+		}
+		log.Println(wholeRow)
+		return nil
+	}
+
+	bv, err := json.Marshal(row)
+	if err != nil {
+		return err
+	}
+	log.Println(string(bv))
+	return nil
+}
+
+func (s *StderrSink) Close() error {
+	return nil
+}
@@ -0,0 +1,47 @@
+//go:build !windows && !plan9
+
+package logger
+
+import (
+	"encoding/json"
+	"log/syslog"
+)
+
+// SyslogSink forwards rows to a local or remote syslog daemon.
+type SyslogSink struct {
+	writer *syslog.Writer
+}
+
+// NewSyslogSink dials the syslog daemon (network and raddr follow
+// (log/syslog).Dial; both empty connects to the local daemon) and returns a
+// SyslogSink that tags messages with tag.
+func NewSyslogSink(network, raddr, tag string) (*SyslogSink, error) {
+	w, err := syslog.Dial(network, raddr, syslog.LOG_INFO|syslog.LOG_USER, tag)
+	if err != nil {
+		return nil, err
+	}
+	return &SyslogSink{writer: w}, nil
+}
+
+func (s *SyslogSink) Write(row *jsonLogRow) error {
+	bv, err := json.Marshal(row)
+	if err != nil {
+		return err
+	}
+	msg := string(bv)
+
+	switch row.Severity {
+	case logFatalString, logErrorString:
+		return s.writer.Err(msg)
+	case logWarningString:
+		return s.writer.Warning(msg)
+	case logDebugString:
+		return s.writer.Debug(msg)
+	default:
+		return s.writer.Info(msg)
+	}
+}
+
+func (s *SyslogSink) Close() error {
+	return s.writer.Close()
+}
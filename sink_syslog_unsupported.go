@@ -0,0 +1,23 @@
+//go:build windows || plan9
+
+package logger
+
+import "errors"
+
+// SyslogSink is unavailable on this platform; log/syslog itself doesn't
+// build here. NewSyslogSink always returns an error so callers fail loudly
+// at the call site instead of the whole module failing to build.
+type SyslogSink struct{}
+
+// NewSyslogSink always fails on this platform; see SyslogSink.
+func NewSyslogSink(network, raddr, tag string) (*SyslogSink, error) {
+	return nil, errors.New("logger: syslog sink is not supported on this platform")
+}
+
+func (s *SyslogSink) Write(row *jsonLogRow) error {
+	return errors.New("logger: syslog sink is not supported on this platform")
+}
+
+func (s *SyslogSink) Close() error {
+	return nil
+}
@@ -0,0 +1,126 @@
+package logger
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+// blockingSink records every row it receives, but the first Write call
+// blocks until unblock is closed, letting a test pile rows up in the
+// sink's queue on purpose.
+type blockingSink struct {
+	unblock chan struct{}
+	started chan struct{}
+	once    sync.Once
+
+	mu      sync.Mutex
+	written []*jsonLogRow
+}
+
+func newBlockingSink() *blockingSink {
+	return &blockingSink{unblock: make(chan struct{}), started: make(chan struct{})}
+}
+
+func (s *blockingSink) Write(row *jsonLogRow) error {
+	s.once.Do(func() { close(s.started) })
+	<-s.unblock
+	s.mu.Lock()
+	s.written = append(s.written, row)
+	s.mu.Unlock()
+	return nil
+}
+
+func (s *blockingSink) Close() error { return nil }
+
+func (s *blockingSink) count() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.written)
+}
+
+func TestSinkDispatchDropsWhenQueueFull(t *testing.T) {
+	sink := newBlockingSink()
+	const name = "test-drop"
+	if err := RegisterSink(name, sink); err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = RemoveSink(name) }()
+
+	row := func() *jsonLogRow { return &jsonLogRow{sevLevel: LogInfo} }
+
+	// Dispatch one row to get the worker goroutine blocked inside Write,
+	// so the rest pile up in the queue rather than being consumed.
+	dispatchToSinks(row())
+	select {
+	case <-sink.started:
+	case <-time.After(time.Second):
+		t.Fatal("worker never picked up the first row")
+	}
+
+	for i := 0; i < sinkQueueSize; i++ {
+		dispatchToSinks(row())
+	}
+	// Queue is now full; this one must be dropped instead of blocking.
+	dispatchToSinks(row())
+
+	var stat *SinkStat
+	for _, s := range SinkStats() {
+		s := s
+		if s.Name == name {
+			stat = &s
+		}
+	}
+	if stat == nil {
+		t.Fatal("SinkStats: no entry for registered sink")
+	}
+	if stat.Queued != sinkQueueSize {
+		t.Fatalf("got Queued=%d, want %d", stat.Queued, sinkQueueSize)
+	}
+	if stat.Dropped != 1 {
+		t.Fatalf("got Dropped=%d, want 1", stat.Dropped)
+	}
+
+	close(sink.unblock)
+	drainSinks()
+
+	if got := sink.count(); got != sinkQueueSize+1 {
+		t.Fatalf("after drain: got %d rows written, want %d", got, sinkQueueSize+1)
+	}
+}
+
+func TestDrainSinksWaitsForPendingRows(t *testing.T) {
+	sink := newBlockingSink()
+	const name = "test-drain"
+	if err := RegisterSink(name, sink); err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = RemoveSink(name) }()
+
+	dispatchToSinks(&jsonLogRow{sevLevel: LogInfo})
+	select {
+	case <-sink.started:
+	case <-time.After(time.Second):
+		t.Fatal("worker never picked up the row")
+	}
+
+	done := make(chan struct{})
+	go func() {
+		drainSinks()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		t.Fatal("drainSinks returned before the blocked Write finished")
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	close(sink.unblock)
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("drainSinks never returned after Write unblocked")
+	}
+}
@@ -0,0 +1,152 @@
+package logger
+
+import (
+	"regexp"
+	"strconv"
+	"sync/atomic"
+)
+
+// subscriberQueueSize bounds how many events a subscriber may have pending
+// before new events start being dropped for it instead of blocking the
+// logging call site.
+const subscriberQueueSize = 256
+
+// LogEvent is the structured form of a logged row delivered to subscribers.
+type LogEvent struct {
+	Timestamp  string
+	FileName   string
+	LineNumber int
+	MethodName string
+	Message    string
+	Severity   int
+	Facility   string
+	Event      interface{}
+	Fields     map[string]interface{}
+	TraceId    string
+	SpanId     string
+	TraceFlags string
+}
+
+// SubscribeFilter narrows the events a subscriber receives.
+type SubscribeFilter struct {
+	// MinSeverity selects events at least this severe, using the package's
+	// LogFatal..LogDebug ordering (lower values are more severe). Pass
+	// LogDebug to receive every severity.
+	MinSeverity int
+
+	// Facilities, if non-empty, restricts delivery to events logged against
+	// one of these facility names.
+	Facilities []string
+
+	// Match, if non-nil, restricts delivery to events whose message matches
+	// the regular expression (compile a regexp.QuoteMeta'd string for a
+	// plain substring search).
+	Match *regexp.Regexp
+}
+
+func (f SubscribeFilter) matches(row *jsonLogRow) bool {
+	if row.sevLevel > f.MinSeverity {
+		return false
+	}
+	if len(f.Facilities) > 0 {
+		found := false
+		for _, name := range f.Facilities {
+			if name == row.Facility {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	if f.Match != nil && !f.Match.MatchString(row.Message) {
+		return false
+	}
+	return true
+}
+
+type subscriber struct {
+	filter  SubscribeFilter
+	ch      chan *LogEvent
+	dropped uint64
+}
+
+var subscriberRegistry = NewConcurrentMap()
+var subscriberSeq uint64
+
+// Subscribe registers a subscriber matching filter and returns a channel of
+// the log events emitted from that point on, plus a cancel function that
+// unregisters the subscriber and closes the channel. A slow consumer that
+// doesn't keep up has events dropped for it rather than blocking callers;
+// see SubscriberStats.
+func Subscribe(filter SubscribeFilter) (<-chan *LogEvent, func()) {
+	id := strconv.FormatUint(atomic.AddUint64(&subscriberSeq, 1), 10)
+	sub := &subscriber{filter: filter, ch: make(chan *LogEvent, subscriberQueueSize)}
+	subscriberRegistry.Set(id, sub)
+
+	cancel := func() {
+		subscriberRegistry.Remove(id)
+		close(sub.ch)
+	}
+	return sub.ch, cancel
+}
+
+// SubscriberStat reports the health of a single active subscription.
+type SubscriberStat struct {
+	Dropped uint64
+	Queued  int
+}
+
+// SubscriberStats returns a point-in-time snapshot of every active
+// subscriber's dropped-event counter and current queue depth.
+func SubscriberStats() []SubscriberStat {
+	stats := make([]SubscriberStat, 0, subscriberRegistry.Count())
+	subscriberRegistry.IteratorCb(func(_ string, v interface{}) {
+		sub := v.(*subscriber)
+		stats = append(stats, SubscriberStat{
+			Dropped: atomic.LoadUint64(&sub.dropped),
+			Queued:  len(sub.ch),
+		})
+	})
+	return stats
+}
+
+func publishToSubscribers(row *jsonLogRow) {
+	if subscriberRegistry.IsEmpty() {
+		return
+	}
+
+	var event *LogEvent
+	subscriberRegistry.IteratorCb(func(_ string, v interface{}) {
+		sub := v.(*subscriber)
+		if !sub.filter.matches(row) {
+			return
+		}
+		if event == nil {
+			event = rowToEvent(row)
+		}
+		select {
+		case sub.ch <- event:
+		default:
+			atomic.AddUint64(&sub.dropped, 1)
+		}
+	})
+}
+
+func rowToEvent(row *jsonLogRow) *LogEvent {
+	return &LogEvent{
+		Timestamp:  row.Timestamp,
+		FileName:   row.FileName,
+		LineNumber: row.LineNumber,
+		MethodName: row.MethodName,
+		Message:    row.Message,
+		Severity:   row.sevLevel,
+		Facility:   row.Facility,
+		Event:      row.Event,
+		Fields:     row.Fields,
+		TraceId:    row.TraceId,
+		SpanId:     row.SpanId,
+		TraceFlags: row.TraceFlags,
+	}
+}
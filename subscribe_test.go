@@ -0,0 +1,70 @@
+package logger
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSubscribeDeliversMatchingEvents(t *testing.T) {
+	ch, cancel := Subscribe(SubscribeFilter{MinSeverity: LogInfo})
+	defer cancel()
+
+	publishToSubscribers(&jsonLogRow{sevLevel: LogInfo, Message: "hello"})
+
+	select {
+	case ev := <-ch:
+		if ev.Message != "hello" {
+			t.Fatalf("got message %q, want %q", ev.Message, "hello")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for event")
+	}
+}
+
+func TestSubscribeFilterExcludesLessSevere(t *testing.T) {
+	ch, cancel := Subscribe(SubscribeFilter{MinSeverity: LogError})
+	defer cancel()
+
+	publishToSubscribers(&jsonLogRow{sevLevel: LogDebug, Message: "noise"})
+
+	select {
+	case ev := <-ch:
+		t.Fatalf("unexpected event delivered: %+v", ev)
+	case <-time.After(20 * time.Millisecond):
+	}
+}
+
+func TestSubscriberStatsReportsDropped(t *testing.T) {
+	ch, cancel := Subscribe(SubscribeFilter{MinSeverity: LogDebug})
+	defer cancel()
+
+	for i := 0; i < subscriberQueueSize+5; i++ {
+		publishToSubscribers(&jsonLogRow{sevLevel: LogDebug, Message: "x"})
+	}
+
+	var found bool
+	for _, s := range SubscriberStats() {
+		if s.Queued == subscriberQueueSize {
+			found = true
+			if s.Dropped == 0 {
+				t.Fatalf("got Dropped=0 for a full queue, want > 0")
+			}
+		}
+	}
+	if !found {
+		t.Fatal("SubscriberStats: no entry with a full queue")
+	}
+
+	_ = ch
+}
+
+func TestSubscribeCancelStopsDeliveryWithoutPanic(t *testing.T) {
+	ch, cancel := Subscribe(SubscribeFilter{MinSeverity: LogDebug})
+	cancel()
+
+	publishToSubscribers(&jsonLogRow{sevLevel: LogDebug, Message: "after cancel"})
+
+	if _, ok := <-ch; ok {
+		t.Fatal("expected channel to be closed after cancel")
+	}
+}